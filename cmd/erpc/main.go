@@ -0,0 +1,471 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/flair-sdk/erpc/config"
+	"github.com/flair-sdk/erpc/erpc"
+	"github.com/flair-sdk/erpc/supervisor"
+	"github.com/flair-sdk/erpc/telemetry"
+	"github.com/flair-sdk/erpc/upstream/discovery/docker"
+	"github.com/flair-sdk/erpc/util"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// subcommands recognized as args[1]. Anything else (including a bare
+// config file path) falls back to "serve" for backward compatibility
+// with versions that took no subcommand at all.
+const (
+	cmdServe       = "serve"
+	cmdConfigCheck = "config-check"
+	cmdConfigDump  = "config-dump"
+)
+
+func main() {
+	mainWithFs(afero.NewOsFs())
+}
+
+// mainWithFs is main's body, parameterized on the filesystem so tests can
+// exercise subcommand dispatch against an in-memory afero.Fs the same way
+// Init already does.
+func mainWithFs(fs afero.Fs) {
+	cmd, cmdArgs := parseCommand(os.Args)
+
+	switch cmd {
+	case cmdConfigCheck:
+		runConfigCheck(fs, cmdArgs)
+	case cmdConfigDump:
+		runConfigDump(fs, cmdArgs)
+	default:
+		runServe(fs, cmdArgs)
+	}
+}
+
+// parseCommand splits os.Args into a subcommand and the remaining args
+// (with args[0] preserved as the program name, so the result can be fed
+// straight into Init/LoadConfig the same way a bare config path always
+// could). If args[1] isn't one of the known subcommands, it is assumed to
+// be a config path and the command defaults to "serve".
+func parseCommand(args []string) (string, []string) {
+	if len(args) < 2 {
+		return cmdServe, args
+	}
+
+	switch args[1] {
+	case cmdServe, cmdConfigCheck, cmdConfigDump:
+		rest := append([]string{args[0]}, args[2:]...)
+		return args[1], rest
+	default:
+		return cmdServe, args
+	}
+}
+
+func runServe(fs afero.Fs, args []string) {
+	shutdown, err := Init(fs, args)
+	if err != nil {
+		var hErr *httpServerError
+		if errors.As(err, &hErr) {
+			log.Error().Err(err).Msg("http server failed to start")
+			util.OsExit(util.ExitCodeHttpServerFailed)
+		} else {
+			log.Error().Err(err).Msg("failed to start erpc")
+			util.OsExit(util.ExitCodeERPCStartFailed)
+		}
+		return
+	}
+	defer shutdown()
+
+	select {}
+}
+
+// runConfigCheck loads and bootstraps the config the exact same way serve
+// does, but never opens the HTTP port: it's a dry-run that operators can
+// use to validate upstream metadata and chain IDs before rolling out a
+// change. The config has no rate-limit budgets, so none are checked.
+func runConfigCheck(fs afero.Fs, args []string) {
+	configPath := "erpc.yaml"
+	if len(args) > 1 {
+		configPath = args[1]
+	}
+
+	cfg, err := config.LoadConfig(fs, configPath)
+	if err != nil {
+		log.Error().Err(err).Msg("config-check: failed to start erpc")
+		util.OsExit(util.ExitCodeERPCStartFailed)
+		return
+	}
+
+	if _, err := erpc.NewProjectsRegistry(cfg, log.Logger); err != nil {
+		log.Error().Err(err).Msg("config-check: failed to start erpc")
+		util.OsExit(util.ExitCodeERPCStartFailed)
+		return
+	}
+
+	log.Info().Str("config", configPath).Msg("config-check: configuration is valid")
+}
+
+// runConfigDump loads the config (applying the same defaults serve does)
+// and prints it back out as canonical YAML, so operators can diff what
+// erpc actually resolved against what they wrote.
+func runConfigDump(fs afero.Fs, args []string) {
+	configPath := "erpc.yaml"
+	if len(args) > 1 {
+		configPath = args[1]
+	}
+
+	cfg, err := config.LoadConfig(fs, configPath)
+	if err != nil {
+		log.Error().Err(err).Msg("config-dump: failed to start erpc")
+		util.OsExit(util.ExitCodeERPCStartFailed)
+		return
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		log.Error().Err(err).Msg("config-dump: failed to marshal configuration")
+		util.OsExit(util.ExitCodeERPCStartFailed)
+		return
+	}
+
+	fmt.Print(string(out))
+}
+
+// httpServerError wraps errors coming from the HTTP listener so main can
+// tell them apart from config/bootstrap errors and pick the right exit
+// code.
+type httpServerError struct{ err error }
+
+func (e *httpServerError) Error() string { return e.err.Error() }
+func (e *httpServerError) Unwrap() error { return e.err }
+
+// reloadableState bundles the base config/registry pair that a SIGHUP
+// reload replaces, plus the latest set of Docker-discovered upstreams
+// per project. "Base" means cfg.Projects[*].Upstreams always holds only
+// the statically configured upstreams, never any discovered ones, so a
+// SIGHUP reload and a Docker discovery event can each recompute the live
+// registry from base+discovered without needing to know about the
+// other's last change. setDiscovered and reloadFrom each hold mu for
+// their whole compute-and-swap, so concurrent SIGHUPs and discovery
+// events from multiple projects' Watch goroutines can't interleave and
+// lose one side's update.
+type reloadableState struct {
+	mu         sync.Mutex
+	cfg        *config.Config
+	registry   *erpc.ProjectsRegistry
+	discovered map[string][]*config.UpstreamConfig
+}
+
+func newReloadableState(cfg *config.Config, registry *erpc.ProjectsRegistry) *reloadableState {
+	return &reloadableState{
+		cfg:        cfg,
+		registry:   registry,
+		discovered: make(map[string][]*config.UpstreamConfig),
+	}
+}
+
+// setDiscovered records projectId's latest discovered upstreams and
+// rebuilds+swaps the live registry from the base config's static
+// upstreams plus every project's latest discovered upstreams. On a
+// bootstrap failure the previous live registry is left untouched and the
+// error is returned for the caller to log.
+func (s *reloadableState) setDiscovered(projectId string, discovered []*config.UpstreamConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.discovered[projectId] = discovered
+
+	merged, err := buildMergedRegistry(s.cfg, s.discovered)
+	if err != nil {
+		return err
+	}
+
+	s.registry.Swap(merged)
+	return nil
+}
+
+// reloadFrom swaps in newCfg as the new base config, re-merging every
+// project's latest discovered upstreams into it so a reload never drops
+// them, then applies newCfg's log level. On a bootstrap failure the
+// previous base config/live registry are left untouched and the error is
+// returned for the caller to log.
+func (s *reloadableState) reloadFrom(newCfg *config.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged, err := buildMergedRegistry(newCfg, s.discovered)
+	if err != nil {
+		return err
+	}
+
+	applyLogLevel(newCfg)
+	s.cfg = newCfg
+	s.registry.Swap(merged)
+	return nil
+}
+
+// applyLogLevel parses cfg.LogLevel and, if it's valid, sets it as the
+// global zerolog level. An invalid level is logged and otherwise ignored,
+// leaving the previous global level in place rather than forcing a
+// default.
+func applyLogLevel(cfg *config.Config) {
+	level, err := zerolog.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Error().Err(err).Str("logLevel", cfg.LogLevel).Msg("invalid log level, leaving current level unchanged")
+		return
+	}
+	zerolog.SetGlobalLevel(level)
+}
+
+// Init loads the configuration found at args[1], bootstraps the projects
+// registry, and starts every service (HTTP listener, optional metrics and
+// websocket listeners, the cache-eviction loop, and Docker discovery
+// workers) through a supervisor.Supervisor, so a failure partway through
+// boot never leaves some of them running. It also installs a SIGHUP
+// handler that re-reads the same file from fs and atomically swaps in a
+// freshly bootstrapped registry. It returns a shutdown func that stops
+// every service (within server.shutdownTimeout) and the SIGHUP watcher.
+func Init(fs afero.Fs, args []string) (func(), error) {
+	configPath := "erpc.yaml"
+	if len(args) > 1 {
+		configPath = args[1]
+	}
+
+	cfg, err := config.LoadConfig(fs, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	applyLogLevel(cfg)
+
+	registry, err := erpc.NewProjectsRegistry(cfg, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	state := newReloadableState(cfg, registry)
+
+	var sinkCfgs []*config.TelemetrySinkConfig
+	if cfg.Telemetry != nil {
+		sinkCfgs = cfg.Telemetry.Sinks
+	}
+	telemetryRegistry, err := telemetry.NewRegistry(sinkCfgs, log.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize telemetry: %w", err)
+	}
+
+	httpServer := erpc.NewHttpServer(cfg.Server, registry, telemetryRegistry, log.Logger)
+
+	// The HTTP listener is started last, so that if its port is taken,
+	// every other service that already started (metrics, discovery, ...)
+	// gets torn down by the supervisor's unwind instead of leaking.
+	services := []supervisor.Service{erpc.NewCacheEvictionService()}
+
+	if cfg.Server.MetricsPort != "" {
+		if ps := telemetryRegistry.PrometheusSink(); ps != nil {
+			metricsAddr := fmt.Sprintf("%s:%s", cfg.Server.HttpHost, cfg.Server.MetricsPort)
+			services = append(services, erpc.NewMetricsServer(metricsAddr, ps.Handler(), log.Logger))
+		}
+	}
+
+	if cfg.Server.WsPort != "" {
+		wsAddr := fmt.Sprintf("%s:%s", cfg.Server.HttpHost, cfg.Server.WsPort)
+		services = append(services, erpc.NewWsServer(wsAddr, log.Logger))
+	}
+
+	services = append(services, newDockerDiscoveryService(cfg, state))
+	services = append(services, httpServer)
+
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+
+	sv := supervisor.NewSupervisor(services...)
+	if err := sv.Start(rootCtx); err != nil {
+		cancelRoot()
+		telemetryRegistry.Close()
+
+		var startErr *supervisor.StartError
+		if errors.As(err, &startErr) && startErr.Service == "http" {
+			return nil, &httpServerError{err}
+		}
+		return nil, err
+	}
+
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-hupCh:
+				reload(fs, configPath, state)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	shutdownTimeout := 10 * time.Second
+	if d, err := time.ParseDuration(cfg.Server.ShutdownTimeout); err == nil {
+		shutdownTimeout = d
+	}
+
+	shutdown := func() {
+		signal.Stop(hupCh)
+		close(done)
+		cancelRoot()
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := sv.Stop(ctx); err != nil {
+			log.Error().Err(err).Msg("one or more services failed to stop cleanly")
+		}
+
+		telemetryRegistry.Flush()
+		telemetryRegistry.Close()
+	}
+
+	return shutdown, nil
+}
+
+// dockerDiscoveryService adapts the per-project Docker discoverers to
+// the supervisor.Service interface: Start runs each project's initial
+// enumeration synchronously (so Init doesn't return until the first
+// batch of discovered upstreams is already routable) and spawns the
+// watch goroutines; Stop cancels them.
+type dockerDiscoveryService struct {
+	cfg    *config.Config
+	state  *reloadableState
+	cancel context.CancelFunc
+}
+
+func newDockerDiscoveryService(cfg *config.Config, state *reloadableState) *dockerDiscoveryService {
+	return &dockerDiscoveryService{cfg: cfg, state: state}
+}
+
+func (d *dockerDiscoveryService) Name() string { return "docker-discovery" }
+
+func (d *dockerDiscoveryService) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+
+	for _, pc := range d.cfg.Projects {
+		if pc.Discovery == nil || pc.Discovery.Docker == nil {
+			continue
+		}
+
+		projectId := pc.Id
+		discoverer := docker.NewDiscoverer(pc.Discovery.Docker, log.Logger)
+
+		apply := func(discovered []*config.UpstreamConfig) {
+			applyDiscoveredUpstreams(d.state, projectId, discovered)
+		}
+
+		discovered, err := discoverer.Discover(ctx)
+		if err != nil {
+			log.Error().Err(err).Str("project", projectId).Msg("docker discovery: initial enumeration failed")
+		} else {
+			apply(discovered)
+		}
+
+		go discoverer.Watch(ctx, apply)
+	}
+
+	return nil
+}
+
+func (d *dockerDiscoveryService) Stop(ctx context.Context) error {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	return nil
+}
+
+// applyDiscoveredUpstreams records projectId's latest discovered
+// upstreams and recomputes+swaps the live registry for every
+// discovery-enabled project from its current base (static) upstreams
+// plus its latest discovered upstreams, so a Docker event for one
+// project can never clobber a log-level (or other) change a SIGHUP just
+// applied, or vice versa. A bootstrap failure is logged and the
+// previously live registry is left untouched.
+func applyDiscoveredUpstreams(state *reloadableState, projectId string, discovered []*config.UpstreamConfig) {
+	if err := state.setDiscovered(projectId, discovered); err != nil {
+		log.Error().Err(err).Str("project", projectId).Msg("docker discovery: failed to bootstrap discovered upstreams, keeping previous")
+	}
+}
+
+// filterValidUpstreams drops any discovered upstream missing the
+// evmChainId metadata that bootstrapProject requires, logging a warning
+// for each one skipped. It's applied before merging discovered upstreams
+// into the live config so a single malformed container label can't fail
+// bootstrap for the whole project.
+func filterValidUpstreams(projectId string, discovered []*config.UpstreamConfig) []*config.UpstreamConfig {
+	valid := make([]*config.UpstreamConfig, 0, len(discovered))
+	for _, uc := range discovered {
+		if uc.Metadata == nil || uc.Metadata.EvmChainId == 0 {
+			log.Warn().Str("project", projectId).Str("upstream", uc.Id).Msg("docker discovery: skipping upstream with missing or invalid evmChainId label")
+			continue
+		}
+		valid = append(valid, uc)
+	}
+	return valid
+}
+
+// buildMergedRegistry bootstraps a *erpc.ProjectsRegistry from base with
+// every discovery-enabled project's Upstreams replaced by base's own
+// (static) Upstreams for that project plus its latest entry in
+// discovered, filtered through filterValidUpstreams. Projects without
+// Docker discovery configured, or with no discovered upstreams yet, are
+// passed through unchanged. base itself is never mutated, so it always
+// stays a valid "static only" snapshot to merge from again later.
+func buildMergedRegistry(base *config.Config, discovered map[string][]*config.UpstreamConfig) (*erpc.ProjectsRegistry, error) {
+	merged := *base
+	merged.Projects = make([]*config.ProjectConfig, len(base.Projects))
+
+	for i, pc := range base.Projects {
+		d, ok := discovered[pc.Id]
+		if !ok || pc.Discovery == nil || pc.Discovery.Docker == nil {
+			merged.Projects[i] = pc
+			continue
+		}
+
+		pcCopy := *pc
+		pcCopy.Upstreams = append(append([]*config.UpstreamConfig{}, pc.Upstreams...), filterValidUpstreams(pc.Id, d)...)
+		merged.Projects[i] = &pcCopy
+	}
+
+	return erpc.NewProjectsRegistry(&merged, log.Logger)
+}
+
+// reload re-reads configPath and swaps it in as the new base config (see
+// reloadableState), re-merging every project's latest discovered
+// upstreams into it so a SIGHUP never silently drops them, and updates
+// the log level to match. The live HTTP server keeps its
+// *erpc.ProjectsRegistry pointer for its whole lifetime; reload swaps
+// that registry's internal project map under its own lock via Swap, so
+// in-flight requests always see a complete, consistent set of projects
+// and the HTTP listener itself is never dropped. Any failure is logged
+// and the previous config is kept.
+func reload(fs afero.Fs, configPath string, state *reloadableState) {
+	newCfg, err := config.LoadConfig(fs, configPath)
+	if err != nil {
+		log.Error().Err(err).Msg("SIGHUP reload: failed to load configuration, keeping previous config")
+		return
+	}
+
+	if err := state.reloadFrom(newCfg); err != nil {
+		log.Error().Err(err).Msg("SIGHUP reload: failed to bootstrap new configuration, keeping previous config")
+		return
+	}
+
+	log.Info().Msg("SIGHUP reload: configuration reloaded successfully")
+}
@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"strings"
@@ -40,7 +44,7 @@ server:
 	os.Args = []string{"erpc-test", f.Name()}
 	go main()
 
-	time.Sleep(300)
+	time.Sleep(300 * time.Millisecond)
 
 	// check if the server is running
 	if _, err := http.Get(localBaseUrl); err != nil {
@@ -80,12 +84,18 @@ func TestMain_InvalidHttpPort(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	metricsPort := fmt.Sprint(rand.Intn(1000) + 6000)
 	f.WriteString(`
 logLevel: DEBUG
 
 server:
   httpHost: "localhost"
   httpPort: "-1"
+  metricsPort: "` + metricsPort + `"
+
+telemetry:
+  sinks:
+    - type: prometheus
 `)
 
 	os.Args = []string{"erpc-test", f.Name()}
@@ -110,6 +120,125 @@ server:
 	if !called {
 		t.Error("expected osExit to be called")
 	}
+
+	// The metrics listener starts before the (here, failing) HTTP
+	// listener, so a failed boot must have torn it back down rather than
+	// leaving it running.
+	if conn, err := net.Dial("tcp", "localhost:"+metricsPort); err == nil {
+		conn.Close()
+		t.Error("expected the metrics listener to be stopped after a failed boot")
+	}
+}
+
+func TestMain_ConfigCheck_Success(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cfg, err := afero.TempFile(fs, "", "erpc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.WriteString(`
+logLevel: DEBUG
+
+projects:
+  - id: main
+    upstreams:
+    - id: good-evm-rpc
+      endpoint: http://google.com
+      metadata:
+        evmChainId: 1
+`)
+
+	os.Args = []string{"erpc-test", "config-check", cfg.Name()}
+
+	originalOsExit := util.OsExit
+	var called bool
+	defer func() { util.OsExit = originalOsExit }()
+	util.OsExit = func(code int) { called = true }
+
+	mainWithFs(fs)
+
+	if called {
+		t.Error("expected osExit not to be called for a valid config")
+	}
+}
+
+func TestMain_ConfigCheck_BootstrapFailure(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cfg, err := afero.TempFile(fs, "", "erpc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.WriteString(`
+logLevel: DEBUG
+
+projects:
+  - id: main
+    upstreams:
+    - id: good-evm-rpc
+      endpoint: http://google.com
+      # NOT providing chain ID will cause the bootstrap to fail
+`)
+
+	os.Args = []string{"erpc-test", "config-check", cfg.Name()}
+
+	originalOsExit := util.OsExit
+	var called bool
+	defer func() { util.OsExit = originalOsExit }()
+	util.OsExit = func(code int) {
+		if code != util.ExitCodeERPCStartFailed {
+			t.Errorf("expected code %d, got %d", util.ExitCodeERPCStartFailed, code)
+		} else {
+			called = true
+		}
+	}
+
+	mainWithFs(fs)
+
+	if !called {
+		t.Error("expected osExit to be called")
+	}
+}
+
+func TestMain_ConfigDump(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cfg, err := afero.TempFile(fs, "", "erpc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.WriteString(`
+projects:
+  - id: main
+    upstreams:
+    - id: good-evm-rpc
+      endpoint: http://google.com
+      metadata:
+        evmChainId: 1
+`)
+
+	os.Args = []string{"erpc-test", "config-dump", cfg.Name()}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalStdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = originalStdout }()
+
+	mainWithFs(fs)
+
+	w.Close()
+	dumped, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(dumped), "good-evm-rpc") {
+		t.Errorf("expected dumped config to contain the upstream id, got: %s", dumped)
+	}
+	if !strings.Contains(string(dumped), "logLevel: INFO") {
+		t.Errorf("expected dumped config to include the resolved default logLevel, got: %s", dumped)
+	}
 }
 
 func TestInit_HappyPath(t *testing.T) {
@@ -169,7 +298,7 @@ projects:
 		Post("").
 		MatchType("json").
 		JSON(
-			json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x1273c18",false]}`),
+			json.RawMessage(`{"jsonrpc":"2.0","id":91799,"method":"eth_getBlockByNumber","params":["0x1273c18",false]}`),
 		).
 		Reply(200).
 		JSON(json.RawMessage(`{"result":{"hash":"0x64d340d2470d2ed0ec979b72d79af9cd09fc4eb2b89ae98728d5fb07fd89baf9"}}`))
@@ -209,8 +338,19 @@ projects:
 		t.Fatalf("error unmarshalling: %s response body: %s", err, respBody)
 	}
 
-	if respObject["hash"] != "0x64d340d2470d2ed0ec979b72d79af9cd09fc4eb2b89ae98728d5fb07fd89baf9" {
-		t.Errorf("unexpected hash, got %s", respObject["hash"])
+	if respObject["jsonrpc"] != "2.0" {
+		t.Errorf("expected jsonrpc 2.0, got %v", respObject["jsonrpc"])
+	}
+	if respObject["id"] != float64(91799) {
+		t.Errorf("expected response id to echo the request's id 91799, got %v", respObject["id"])
+	}
+
+	result, ok := respObject["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a result object, got %s", respBody)
+	}
+	if result["hash"] != "0x64d340d2470d2ed0ec979b72d79af9cd09fc4eb2b89ae98728d5fb07fd89baf9" {
+		t.Errorf("unexpected hash, got %s", result["hash"])
 	}
 }
 
@@ -280,6 +420,331 @@ func TestInit_InvalidLogLevel(t *testing.T) {
 	}
 }
 
+func TestInit_SighupReload(t *testing.T) {
+	defer gock.Disable()
+	defer gock.DisableNetworking()
+	defer gock.DisableNetworkingFilters()
+
+	gock.EnableNetworking()
+	gock.NetworkingFilter(func(req *http.Request) bool {
+		shouldMakeRealCall := strings.Split(req.URL.Host, ":")[0] == "localhost"
+		return shouldMakeRealCall
+	})
+
+	//
+	// 1) Initialize the eRPC server with only the original upstream.
+	//
+	fs := afero.NewMemMapFs()
+	cfgPath := "/erpc.yaml"
+	cfg, err := fs.Create(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localHost := "localhost"
+	localPort := fmt.Sprint(rand.Intn(1000) + 2000)
+	localBaseUrl := fmt.Sprintf("http://localhost:%s", localPort)
+	cfg.WriteString(`
+logLevel: DEBUG
+
+server:
+  httpHost: "` + localHost + `"
+  httpPort: ` + localPort + `
+
+projects:
+  - id: main
+    upstreams:
+    - id: good-evm-rpc
+      endpoint: http://google.com
+      metadata:
+        evmChainId: 1
+`)
+	cfg.Close()
+
+	args := []string{"erpc-test", cfgPath}
+
+	shutdown, err := Init(fs, args)
+	if shutdown != nil {
+		defer shutdown()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//
+	// 2) Rewrite the same file with a second, newly-added upstream and
+	// send SIGHUP so Init's reload handler picks it up.
+	//
+	cfg2, err := fs.Create(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg2.WriteString(`
+logLevel: DEBUG
+
+server:
+  httpHost: "` + localHost + `"
+  httpPort: ` + localPort + `
+
+projects:
+  - id: main
+    upstreams:
+    - id: new-evm-rpc
+      endpoint: http://new-upstream.test
+      metadata:
+        evmChainId: 1
+`)
+	cfg2.Close()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	//
+	// 3) Traffic should now be routed to the newly-added mock upstream.
+	//
+	gock.New("http://new-upstream.test").
+		Post("").
+		Reply(200).
+		JSON(json.RawMessage(`{"result":{"hash":"0xreloaded"}}`))
+
+	body := bytes.NewBuffer([]byte(`
+		{
+			"method": "eth_getBlockByNumber",
+			"params": ["0x1273c18", false],
+			"id": 91799,
+			"jsonrpc": "2.0"
+		}
+	`))
+	res, err := http.Post(fmt.Sprintf("%s/main/1", localBaseUrl), "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("error reading response: %s", err)
+	}
+
+	respObject := make(map[string]interface{})
+	if err := json.Unmarshal(respBody, &respObject); err != nil {
+		t.Fatalf("error unmarshalling: %s response body: %s", err, respBody)
+	}
+
+	result, _ := respObject["result"].(map[string]interface{})
+	if result["hash"] != "0xreloaded" {
+		t.Errorf("expected reload to route to the new upstream, got %v", respObject)
+	}
+}
+
+func TestInit_DockerDiscovery(t *testing.T) {
+	//
+	// 1) Fake Docker Engine API over a real Unix socket, returning a
+	// single running container that carries erpc.upstream.* labels.
+	//
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"hash":"0xdiscovered"}}`))
+	}))
+	defer upstream.Close()
+
+	sockPath := filepath.Join(t.TempDir(), "docker.sock")
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dockerMux := http.NewServeMux()
+	dockerMux.HandleFunc("/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"Id":"abc123","Labels":{"erpc.upstream.id":"discovered-evm","erpc.upstream.endpoint":"` + upstream.URL + `","erpc.upstream.evmChainId":"1"}}]`))
+	})
+	dockerMux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	dockerSrv := &http.Server{Handler: dockerMux}
+	go dockerSrv.Serve(ln)
+	defer dockerSrv.Close()
+
+	//
+	// 2) Initialize erpc with no static upstreams, only Docker discovery.
+	//
+	fs := afero.NewMemMapFs()
+	cfg, err := afero.TempFile(fs, "", "erpc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localHost := "localhost"
+	localPort := fmt.Sprint(rand.Intn(1000) + 2000)
+	localBaseUrl := fmt.Sprintf("http://localhost:%s", localPort)
+	cfg.WriteString(`
+logLevel: DEBUG
+
+server:
+  httpHost: "` + localHost + `"
+  httpPort: ` + localPort + `
+
+projects:
+  - id: main
+    discovery:
+      docker:
+        socket: "` + sockPath + `"
+`)
+
+	args := []string{"erpc-test", cfg.Name()}
+
+	shutdown, err := Init(fs, args)
+	if shutdown != nil {
+		defer shutdown()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//
+	// 3) An EVM request should get routed to the discovered upstream.
+	//
+	body := bytes.NewBuffer([]byte(`
+		{
+			"method": "eth_getBlockByNumber",
+			"params": ["0x1273c18", false],
+			"id": 91799,
+			"jsonrpc": "2.0"
+		}
+	`))
+	res, err := http.Post(fmt.Sprintf("%s/main/1", localBaseUrl), "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("error reading response: %s", err)
+	}
+
+	respObject := make(map[string]interface{})
+	if err := json.Unmarshal(respBody, &respObject); err != nil {
+		t.Fatalf("error unmarshalling: %s response body: %s", err, respBody)
+	}
+
+	result, _ := respObject["result"].(map[string]interface{})
+	if result["hash"] != "0xdiscovered" {
+		t.Errorf("expected request to route to the discovered upstream, got %v", respObject)
+	}
+}
+
+func TestInit_StatsDTelemetry(t *testing.T) {
+	defer gock.Disable()
+	defer gock.DisableNetworking()
+	defer gock.DisableNetworkingFilters()
+
+	gock.EnableNetworking()
+	gock.NetworkingFilter(func(req *http.Request) bool {
+		shouldMakeRealCall := strings.Split(req.URL.Host, ":")[0] == "localhost"
+		return shouldMakeRealCall
+	})
+
+	//
+	// 1) A fake StatsD server that forwards every packet it gets to
+	// packets; the test checks the first one.
+	//
+	udpAddr, err := net.ResolveUDPAddr("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	packets := make(chan string, 16)
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			packets <- string(buf[:n])
+		}
+	}()
+
+	//
+	// 2) Initialize erpc with a statsd telemetry sink pointed at it.
+	//
+	fs := afero.NewMemMapFs()
+	cfg, err := afero.TempFile(fs, "", "erpc.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localHost := "localhost"
+	localPort := fmt.Sprint(rand.Intn(1000) + 2000)
+	localBaseUrl := fmt.Sprintf("http://localhost:%s", localPort)
+	cfg.WriteString(`
+logLevel: DEBUG
+
+server:
+  httpHost: "` + localHost + `"
+  httpPort: ` + localPort + `
+
+telemetry:
+  sinks:
+    - type: statsd
+      addr: "` + conn.LocalAddr().String() + `"
+
+projects:
+  - id: main
+    upstreams:
+    - id: good-evm-rpc
+      endpoint: http://google.com
+      metadata:
+        evmChainId: 1
+`)
+
+	args := []string{"erpc-test", cfg.Name()}
+
+	shutdown, err := Init(fs, args)
+	if shutdown != nil {
+		defer shutdown()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gock.New("http://google.com").
+		Post("").
+		Reply(200).
+		JSON(json.RawMessage(`{"result":{"hash":"0x1"}}`))
+
+	body := bytes.NewBuffer([]byte(`
+		{
+			"method": "eth_getBlockByNumber",
+			"params": ["0x1273c18", false],
+			"id": 1,
+			"jsonrpc": "2.0"
+		}
+	`))
+	res, err := http.Post(fmt.Sprintf("%s/main/1", localBaseUrl), "application/json", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	select {
+	case p := <-packets:
+		if !strings.Contains(p, "erpc.request") {
+			t.Errorf("expected an erpc.request counter, got: %s", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a statsd packet")
+	}
+}
+
 func TestInit_BootstrapFailure(t *testing.T) {
 	fs := afero.NewMemMapFs()
 
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerConfig controls the main HTTP JSON-RPC listener, and optionally
+// a couple of others started alongside it.
+type ServerConfig struct {
+	HttpHost string `yaml:"httpHost"`
+	HttpPort string `yaml:"httpPort"`
+
+	// MetricsPort, if set and a prometheus telemetry sink is configured,
+	// starts a standalone /metrics listener on HttpHost:MetricsPort
+	// instead of mounting it on the main listener.
+	MetricsPort string `yaml:"metricsPort"`
+
+	// WsPort, if set, starts an (currently scaffolded) WebSocket listener
+	// on HttpHost:WsPort alongside the main HTTP listener.
+	WsPort string `yaml:"wsPort"`
+
+	// ShutdownTimeout bounds how long Init's shutdown func waits for every
+	// service to stop, e.g. "10s". Defaults to 10s.
+	ShutdownTimeout string `yaml:"shutdownTimeout"`
+}
+
+// MetadataConfig holds upstream metadata used to route and validate
+// requests. EvmChainId is required for every upstream so requests can be
+// matched to the right chain during bootstrap.
+type MetadataConfig struct {
+	EvmChainId int64 `yaml:"evmChainId"`
+}
+
+type UpstreamConfig struct {
+	Id       string          `yaml:"id"`
+	Endpoint string          `yaml:"endpoint"`
+	Group    string          `yaml:"group"`
+	Metadata *MetadataConfig `yaml:"metadata"`
+
+	// Labels carries the discovering container's own labels when this
+	// upstream came from a discovery subsystem (e.g. DockerDiscoveryConfig)
+	// rather than the static YAML. Not user-configurable.
+	Labels map[string]string `yaml:"-"`
+}
+
+// DiscoveryConfig configures dynamic upstream discovery for a project, on
+// top of (never instead of) its statically configured Upstreams.
+type DiscoveryConfig struct {
+	Docker *DockerDiscoveryConfig `yaml:"docker"`
+}
+
+// DockerDiscoveryConfig enables discovering upstreams from local Docker
+// container labels. Socket defaults to /var/run/docker.sock and
+// LabelPrefix defaults to "erpc.".
+type DockerDiscoveryConfig struct {
+	Socket      string `yaml:"socket"`
+	LabelPrefix string `yaml:"labelPrefix"`
+}
+
+type ProjectConfig struct {
+	Id        string            `yaml:"id"`
+	Upstreams []*UpstreamConfig `yaml:"upstreams"`
+	Discovery *DiscoveryConfig  `yaml:"discovery"`
+}
+
+// TelemetryConfig configures the pluggable metrics/telemetry sinks that
+// the HTTP handler and upstream client emit through.
+type TelemetryConfig struct {
+	Sinks []*TelemetrySinkConfig `yaml:"sinks"`
+}
+
+// TelemetrySinkConfig describes one sink entry. Which fields matter
+// depends on Type: "statsd" uses Addr, "otlp" uses Endpoint,
+// "prometheus" uses none of them. No supported type reads URL;
+// telemetry.NewRegistry rejects "influx_lineproto", like any other
+// unknown type, as unsupported.
+type TelemetrySinkConfig struct {
+	Type     string `yaml:"type"`
+	Addr     string `yaml:"addr"`
+	URL      string `yaml:"url"`
+	Endpoint string `yaml:"endpoint"`
+}
+
+type Config struct {
+	LogLevel  string           `yaml:"logLevel"`
+	Server    *ServerConfig    `yaml:"server"`
+	Projects  []*ProjectConfig `yaml:"projects"`
+	Telemetry *TelemetryConfig `yaml:"telemetry"`
+}
+
+// LoadConfig reads and parses the YAML file at path from fs, applying
+// defaults for any fields left unset. It is the single entry point used
+// by both the long-running server and the one-shot config subcommands,
+// so they all see identical validation behavior.
+func LoadConfig(fs afero.Fs, path string) (*Config, error) {
+	exists, err := afero.Exists(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if config file exists: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("config file %q does not exist", path)
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg.applyDefaults()
+
+	return cfg, nil
+}
+
+func (c *Config) applyDefaults() {
+	if c.LogLevel == "" {
+		c.LogLevel = "INFO"
+	}
+	if c.Server == nil {
+		c.Server = &ServerConfig{}
+	}
+	if c.Server.HttpHost == "" {
+		c.Server.HttpHost = "0.0.0.0"
+	}
+	if c.Server.HttpPort == "" {
+		c.Server.HttpPort = "4000"
+	}
+	if c.Server.ShutdownTimeout == "" {
+		c.Server.ShutdownTimeout = "10s"
+	}
+
+	for _, pc := range c.Projects {
+		if pc.Discovery == nil || pc.Discovery.Docker == nil {
+			continue
+		}
+		if pc.Discovery.Docker.Socket == "" {
+			pc.Discovery.Docker.Socket = "/var/run/docker.sock"
+		}
+		if pc.Discovery.Docker.LabelPrefix == "" {
+			pc.Discovery.Docker.LabelPrefix = "erpc."
+		}
+	}
+}
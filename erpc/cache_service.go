@@ -0,0 +1,48 @@
+package erpc
+
+import (
+	"context"
+	"time"
+)
+
+// CacheEvictionService is a placeholder for the periodic cache-eviction
+// loop. erpc doesn't have a response cache yet, so today it's a no-op
+// that only exists so the supervisor's start/stop lifecycle already has
+// a slot for it once a cache layer lands.
+type CacheEvictionService struct {
+	cancel context.CancelFunc
+}
+
+func NewCacheEvictionService() *CacheEvictionService {
+	return &CacheEvictionService{}
+}
+
+func (s *CacheEvictionService) Name() string { return "cache-eviction" }
+
+func (s *CacheEvictionService) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// no-op until erpc has a cache layer to evict from.
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *CacheEvictionService) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package erpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// MetricsServer is a standalone `/metrics` listener, used instead of
+// mounting metrics on the main HTTP listener when server.metricsPort is
+// configured, so metrics scraping can be firewalled off separately from
+// JSON-RPC traffic.
+type MetricsServer struct {
+	server *http.Server
+	logger zerolog.Logger
+}
+
+func NewMetricsServer(addr string, handler http.Handler, logger zerolog.Logger) *MetricsServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+
+	return &MetricsServer{
+		server: &http.Server{Addr: addr, Handler: mux},
+		logger: logger,
+	}
+}
+
+func (s *MetricsServer) Name() string { return "metrics" }
+
+func (s *MetricsServer) Start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			s.logger.Error().Err(err).Msg("metrics server stopped unexpectedly")
+		}
+	}()
+
+	return nil
+}
+
+func (s *MetricsServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,97 @@
+package erpc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/flair-sdk/erpc/config"
+	"github.com/rs/zerolog"
+)
+
+type Upstream struct {
+	Id       string
+	Endpoint string
+	ChainId  int64
+	Group    string
+}
+
+type Project struct {
+	Id        string
+	Upstreams map[int64][]*Upstream
+}
+
+// ProjectsRegistry is the in-memory source of truth for all configured
+// projects/upstreams. It is safe for concurrent use: reads (request
+// routing) take the read lock, and the whole project map is replaced in
+// one write-locked step so a SIGHUP reload can never be observed half
+// applied.
+type ProjectsRegistry struct {
+	logger zerolog.Logger
+
+	mu       sync.RWMutex
+	projects map[string]*Project
+}
+
+// NewProjectsRegistry bootstraps a registry from cfg, validating every
+// upstream along the way. It returns an error (rather than partially
+// registering projects) if any upstream is missing data required to
+// route requests to it, so callers can treat bootstrap as all-or-nothing.
+func NewProjectsRegistry(cfg *config.Config, logger zerolog.Logger) (*ProjectsRegistry, error) {
+	r := &ProjectsRegistry{
+		logger:   logger,
+		projects: make(map[string]*Project),
+	}
+
+	for _, pc := range cfg.Projects {
+		project, err := bootstrapProject(pc)
+		if err != nil {
+			return nil, err
+		}
+		r.projects[pc.Id] = project
+	}
+
+	return r, nil
+}
+
+func bootstrapProject(pc *config.ProjectConfig) (*Project, error) {
+	project := &Project{
+		Id:        pc.Id,
+		Upstreams: make(map[int64][]*Upstream),
+	}
+
+	for _, uc := range pc.Upstreams {
+		if uc.Metadata == nil || uc.Metadata.EvmChainId == 0 {
+			return nil, fmt.Errorf("cannot bootstrap upstream %q of project %q: missing evmChainId metadata", uc.Id, pc.Id)
+		}
+
+		up := &Upstream{
+			Id:       uc.Id,
+			Endpoint: uc.Endpoint,
+			ChainId:  uc.Metadata.EvmChainId,
+			Group:    uc.Group,
+		}
+		project.Upstreams[up.ChainId] = append(project.Upstreams[up.ChainId], up)
+	}
+
+	return project, nil
+}
+
+func (r *ProjectsRegistry) GetProject(id string) (*Project, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.projects[id]
+	return p, ok
+}
+
+// Swap atomically replaces this registry's projects with another
+// registry's, so in-flight GetProject calls always see a complete set of
+// projects from either the old or the new configuration, never a mix.
+func (r *ProjectsRegistry) Swap(other *ProjectsRegistry) {
+	other.mu.RLock()
+	newProjects := other.projects
+	other.mu.RUnlock()
+
+	r.mu.Lock()
+	r.projects = newProjects
+	r.mu.Unlock()
+}
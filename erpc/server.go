@@ -0,0 +1,181 @@
+package erpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flair-sdk/erpc/config"
+	"github.com/flair-sdk/erpc/telemetry"
+	"github.com/rs/zerolog"
+)
+
+// HttpServer exposes the `/{project}/{chainId}` JSON-RPC proxy endpoint
+// backed by a ProjectsRegistry.
+type HttpServer struct {
+	server    *http.Server
+	registry  *ProjectsRegistry
+	telemetry *telemetry.Registry
+	logger    zerolog.Logger
+}
+
+// NewHttpServer wires up the proxy handler on telemetryRegistry, which
+// must be non-nil: callers that want telemetry disabled should pass a
+// registry built from an empty sink list (telemetry.NewRegistry(nil, ...)),
+// not a nil *telemetry.Registry.
+func NewHttpServer(cfg *config.ServerConfig, registry *ProjectsRegistry, telemetryRegistry *telemetry.Registry, logger zerolog.Logger) *HttpServer {
+	s := &HttpServer{
+		registry:  registry,
+		telemetry: telemetryRegistry,
+		logger:    logger,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleRequest)
+	if ps := telemetryRegistry.PrometheusSink(); ps != nil && cfg.MetricsPort == "" {
+		mux.Handle("/metrics", ps.Handler())
+	}
+
+	s.server = &http.Server{
+		Addr:    fmt.Sprintf("%s:%s", cfg.HttpHost, cfg.HttpPort),
+		Handler: mux,
+	}
+
+	return s
+}
+
+func (s *HttpServer) Name() string { return "http" }
+
+// Start binds the listener synchronously (so a bad port is reported
+// immediately, not racily after some grace period) and then serves on
+// it in the background.
+func (s *HttpServer) Start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			s.logger.Error().Err(err).Msg("http server stopped unexpectedly")
+		}
+	}()
+
+	return nil
+}
+
+func (s *HttpServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *HttpServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) < 2 {
+		http.Error(w, "expected path /{project}/{chainId}", http.StatusBadRequest)
+		return
+	}
+
+	projectId, chainIdStr := segments[0], segments[1]
+	chainId, err := strconv.ParseInt(chainIdStr, 10, 64)
+	if err != nil {
+		http.Error(w, "invalid chain id", http.StatusBadRequest)
+		return
+	}
+
+	project, ok := s.registry.GetProject(projectId)
+	if !ok {
+		http.Error(w, fmt.Sprintf("project %q not found", projectId), http.StatusNotFound)
+		return
+	}
+
+	upstreams := project.Upstreams[chainId]
+	if len(upstreams) == 0 {
+		http.Error(w, fmt.Sprintf("no upstream configured for chain %d", chainId), http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var req map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid json-rpc request", http.StatusBadRequest)
+		return
+	}
+	req["jsonrpc"] = "2.0"
+	reqId := req["id"]
+
+	method, _ := req["method"].(string)
+	tags := telemetry.Tags{ProjectId: projectId, ChainId: chainId, Method: method}
+	s.telemetry.RecordRequest(tags)
+
+	forwarded, err := json.Marshal(req)
+	if err != nil {
+		http.Error(w, "failed to marshal upstream request", http.StatusInternalServerError)
+		return
+	}
+
+	// TODO: load-balance/failover across project.Upstreams[chainId] instead
+	// of always using the first one.
+	upstream := upstreams[0]
+	tags.UpstreamId = upstream.Id
+
+	start := time.Now()
+	resp, err := http.Post(upstream.Endpoint, "application/json", strings.NewReader(string(forwarded)))
+	s.telemetry.RecordUpstreamCall(tags, time.Since(start), err)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("upstream call failed: %v", err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, "failed to read upstream response", http.StatusBadGateway)
+		return
+	}
+
+	var parsed struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		http.Error(w, "invalid upstream response", http.StatusBadGateway)
+		return
+	}
+
+	envelope := jsonRpcResponse{JsonRpc: "2.0", Id: reqId}
+	if parsed.Error != nil {
+		envelope.Error = parsed.Error
+	} else {
+		envelope.Result = parsed.Result
+	}
+
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out)
+}
+
+// jsonRpcResponse is the envelope every proxied response is wrapped in,
+// with Id echoing whatever id the caller sent so responses can be
+// correlated with requests (including in a future batched-request path).
+type jsonRpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      interface{}     `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
@@ -0,0 +1,52 @@
+package erpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/rs/zerolog"
+)
+
+// WsServer is scaffolding for a future JSON-RPC-over-WebSocket listener.
+// Enabling server.wsPort already gives it a place in the supervisor's
+// start/stop lifecycle; today it just reports that the upgrade isn't
+// implemented yet, rather than silently accepting and dropping
+// connections.
+type WsServer struct {
+	server *http.Server
+	logger zerolog.Logger
+}
+
+func NewWsServer(addr string, logger zerolog.Logger) *WsServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "websocket JSON-RPC is not implemented yet", http.StatusNotImplemented)
+	})
+
+	return &WsServer{
+		server: &http.Server{Addr: addr, Handler: mux},
+		logger: logger,
+	}
+}
+
+func (s *WsServer) Name() string { return "websocket" }
+
+func (s *WsServer) Start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			s.logger.Error().Err(err).Msg("websocket server stopped unexpectedly")
+		}
+	}()
+
+	return nil
+}
+
+func (s *WsServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
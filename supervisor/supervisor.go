@@ -0,0 +1,99 @@
+// Package supervisor models erpc's process lifecycle as a fixed list of
+// independent Services (the HTTP listener, an optional WebSocket
+// listener, the metrics listener, discovery workers, ...), modeled after
+// the supervisor pattern used by arvados's lib/boot: start everything in
+// order, stop everything in reverse order, and never leave a half-booted
+// process running.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Service is a long-running component of the erpc process that the
+// Supervisor starts and stops as a unit.
+type Service interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// StartError reports which Service failed to start, so callers can make
+// decisions (e.g. exit codes) based on which one it was.
+type StartError struct {
+	Service string
+	Err     error
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("service %q failed to start: %v", e.Service, e.Err)
+}
+
+func (e *StartError) Unwrap() error { return e.Err }
+
+// Supervisor starts a fixed list of Services in order and stops them in
+// reverse order.
+type Supervisor struct {
+	services []Service
+	started  []Service
+}
+
+func NewSupervisor(services ...Service) *Supervisor {
+	return &Supervisor{services: services}
+}
+
+// Start starts every service in order. If any service fails to start,
+// every service already started is stopped (in reverse order) before
+// Start returns, so a failed boot never leaves part of the process
+// running. The returned error wraps a *StartError identifying the
+// service that failed; if unwinding also hits errors, they're appended
+// to the message but the *StartError remains reachable via errors.As.
+func (sv *Supervisor) Start(ctx context.Context) error {
+	for _, svc := range sv.services {
+		if err := svc.Start(ctx); err != nil {
+			startErr := &StartError{Service: svc.Name(), Err: err}
+
+			if unwindErr := sv.unwind(ctx); unwindErr != nil {
+				return fmt.Errorf("%w (additionally, failed to unwind already-started services: %s)", startErr, unwindErr)
+			}
+			return startErr
+		}
+		sv.started = append(sv.started, svc)
+	}
+	return nil
+}
+
+func (sv *Supervisor) unwind(ctx context.Context) error {
+	var failures []string
+	for i := len(sv.started) - 1; i >= 0; i-- {
+		svc := sv.started[i]
+		if err := svc.Stop(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", svc.Name(), err))
+		}
+	}
+	sv.started = nil
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Stop stops every successfully started service in reverse order,
+// collecting (rather than stopping at) the first failure, so operators
+// see every service that failed to stop cleanly, not just the first.
+func (sv *Supervisor) Stop(ctx context.Context) error {
+	var failures []string
+	for i := len(sv.started) - 1; i >= 0; i-- {
+		svc := sv.started[i]
+		if err := svc.Stop(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", svc.Name(), err))
+		}
+	}
+	sv.started = nil
+	if len(failures) > 0 {
+		return fmt.Errorf("services failed to stop cleanly: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// OTLPSink posts erpc telemetry events as simplified OTLP-HTTP/JSON
+// metric payloads. It intentionally skips the full
+// go.opentelemetry.io SDK: erpc only needs to emit a handful of
+// counters and a latency histogram, not build/export arbitrary spans.
+type OTLPSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewOTLPSink(endpoint string) *OTLPSink {
+	return &OTLPSink{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type otlpEvent struct {
+	Name  string            `json:"name"`
+	Value float64           `json:"value"`
+	Attrs map[string]string `json:"attributes"`
+}
+
+func (s *OTLPSink) post(evt otlpEvent) {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+
+	resp, err := s.client.Post(s.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+func (s *OTLPSink) RecordRequest(tags Tags) {
+	s.post(otlpEvent{
+		Name:  "erpc.request",
+		Value: 1,
+		Attrs: map[string]string{
+			"project":  tags.ProjectId,
+			"chain_id": strconv.FormatInt(tags.ChainId, 10),
+			"method":   tags.Method,
+		},
+	})
+}
+
+func (s *OTLPSink) RecordUpstreamCall(tags Tags, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	s.post(otlpEvent{
+		Name:  "erpc.upstream_call.duration_ms",
+		Value: float64(duration.Milliseconds()),
+		Attrs: map[string]string{
+			"project":  tags.ProjectId,
+			"upstream": tags.UpstreamId,
+			"method":   tags.Method,
+			"outcome":  outcome,
+		},
+	})
+}
+
+func (s *OTLPSink) RecordCacheHit(tags Tags) {
+	s.post(otlpEvent{
+		Name:  "erpc.cache_hit",
+		Value: 1,
+		Attrs: map[string]string{"project": tags.ProjectId, "method": tags.Method},
+	})
+}
+
+func (s *OTLPSink) Flush() error { return nil }
+func (s *OTLPSink) Close() error { return nil }
@@ -0,0 +1,75 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusSink records erpc metrics on its own prometheus.Registry
+// (rather than the global one) so multiple instances - e.g. in tests -
+// never collide over metric registration.
+type PrometheusSink struct {
+	registry             *prometheus.Registry
+	requests             *prometheus.CounterVec
+	upstreamCalls        *prometheus.CounterVec
+	upstreamCallDuration *prometheus.HistogramVec
+	cacheHits            *prometheus.CounterVec
+}
+
+func NewPrometheusSink() *PrometheusSink {
+	s := &PrometheusSink{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erpc_request_total",
+			Help: "Total number of incoming JSON-RPC requests.",
+		}, []string{"project", "chain_id", "method"}),
+		upstreamCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erpc_upstream_call_total",
+			Help: "Total number of upstream calls, labeled by outcome.",
+		}, []string{"project", "upstream", "chain_id", "method", "outcome"}),
+		upstreamCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "erpc_upstream_call_duration_seconds",
+			Help: "Upstream call latency in seconds.",
+		}, []string{"project", "upstream", "chain_id", "method"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "erpc_cache_hit_total",
+			Help: "Total number of cache hits.",
+		}, []string{"project", "method"}),
+	}
+
+	s.registry.MustRegister(s.requests, s.upstreamCalls, s.upstreamCallDuration, s.cacheHits)
+
+	return s
+}
+
+// Handler serves this sink's metrics in the Prometheus exposition
+// format, for mounting under e.g. /metrics.
+func (s *PrometheusSink) Handler() http.Handler {
+	return promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{})
+}
+
+func (s *PrometheusSink) RecordRequest(tags Tags) {
+	chainId := strconv.FormatInt(tags.ChainId, 10)
+	s.requests.WithLabelValues(tags.ProjectId, chainId, tags.Method).Inc()
+}
+
+func (s *PrometheusSink) RecordUpstreamCall(tags Tags, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	chainId := strconv.FormatInt(tags.ChainId, 10)
+	s.upstreamCalls.WithLabelValues(tags.ProjectId, tags.UpstreamId, chainId, tags.Method, outcome).Inc()
+	s.upstreamCallDuration.WithLabelValues(tags.ProjectId, tags.UpstreamId, chainId, tags.Method).Observe(duration.Seconds())
+}
+
+func (s *PrometheusSink) RecordCacheHit(tags Tags) {
+	s.cacheHits.WithLabelValues(tags.ProjectId, tags.Method).Inc()
+}
+
+func (s *PrometheusSink) Flush() error { return nil }
+func (s *PrometheusSink) Close() error { return nil }
@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flair-sdk/erpc/config"
+	"github.com/rs/zerolog"
+)
+
+// Registry fans telemetry events out to every configured Sink, so the
+// HTTP handler and upstream client only ever need to know about the
+// registry, never about individual backends.
+type Registry struct {
+	sinks  []Sink
+	logger zerolog.Logger
+}
+
+// NewRegistry constructs a Sink for each entry in cfgs. If any sink fails
+// to construct, the ones already built are closed and the error is
+// returned, so Init never ends up with a partially-wired registry.
+func NewRegistry(cfgs []*config.TelemetrySinkConfig, logger zerolog.Logger) (*Registry, error) {
+	r := &Registry{logger: logger}
+
+	for _, c := range cfgs {
+		sink, err := newSink(c)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		r.sinks = append(r.sinks, sink)
+	}
+
+	return r, nil
+}
+
+func newSink(c *config.TelemetrySinkConfig) (Sink, error) {
+	switch c.Type {
+	case "prometheus":
+		return NewPrometheusSink(), nil
+	case "statsd":
+		return NewStatsDSink(c.Addr)
+	case "otlp":
+		return NewOTLPSink(c.Endpoint), nil
+	default:
+		return nil, fmt.Errorf("telemetry: unsupported sink type %q", c.Type)
+	}
+}
+
+// PrometheusSink returns the *PrometheusSink registered, if any, so the
+// HTTP server can mount its /metrics handler. Returns nil if no
+// prometheus sink is configured.
+func (r *Registry) PrometheusSink() *PrometheusSink {
+	for _, s := range r.sinks {
+		if ps, ok := s.(*PrometheusSink); ok {
+			return ps
+		}
+	}
+	return nil
+}
+
+func (r *Registry) RecordRequest(tags Tags) {
+	for _, s := range r.sinks {
+		s.RecordRequest(tags)
+	}
+}
+
+func (r *Registry) RecordUpstreamCall(tags Tags, duration time.Duration, err error) {
+	for _, s := range r.sinks {
+		s.RecordUpstreamCall(tags, duration, err)
+	}
+}
+
+func (r *Registry) RecordCacheHit(tags Tags) {
+	for _, s := range r.sinks {
+		s.RecordCacheHit(tags)
+	}
+}
+
+func (r *Registry) Flush() {
+	for _, s := range r.sinks {
+		if err := s.Flush(); err != nil {
+			r.logger.Warn().Err(err).Msg("telemetry: sink flush failed")
+		}
+	}
+}
+
+func (r *Registry) Close() {
+	for _, s := range r.sinks {
+		if err := s.Close(); err != nil {
+			r.logger.Warn().Err(err).Msg("telemetry: sink close failed")
+		}
+	}
+}
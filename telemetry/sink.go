@@ -0,0 +1,29 @@
+// Package telemetry decouples erpc's request/upstream/cache metrics from
+// any single backend. The HTTP handler and upstream client emit through
+// a Registry of Sinks instead of calling Prometheus directly, so an
+// operator can fan the same events out to StatsD, OTLP, etc.
+package telemetry
+
+import "time"
+
+// Tags identify which project/upstream/chain/method a telemetry event
+// belongs to, so every sink builds consistent labels without each one
+// re-deriving them from the request.
+type Tags struct {
+	ProjectId  string
+	UpstreamId string
+	ChainId    int64
+	Method     string
+}
+
+// Sink receives telemetry events from the HTTP handler and the upstream
+// client. erpc has no cache layer, so nothing calls RecordCacheHit
+// today. Implementations must be safe for
+// concurrent use and must not block the request path for long.
+type Sink interface {
+	RecordRequest(tags Tags)
+	RecordUpstreamCall(tags Tags, duration time.Duration, err error)
+	RecordCacheHit(tags Tags)
+	Flush() error
+	Close() error
+}
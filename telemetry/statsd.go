@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// StatsDSink emits erpc.* counters/timers as StatsD UDP packets. Writes
+// are fire-and-forget (UDP, no ack) so they never add latency to the
+// request path.
+type StatsDSink struct {
+	conn *net.UDPConn
+}
+
+func NewStatsDSink(addr string) (*StatsDSink, error) {
+	raddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: invalid statsd addr %q: %w", addr, err)
+	}
+
+	conn, err := net.DialUDP("udp", nil, raddr)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: failed to dial statsd at %q: %w", addr, err)
+	}
+
+	return &StatsDSink{conn: conn}, nil
+}
+
+func (s *StatsDSink) send(metric string) {
+	_, _ = s.conn.Write([]byte(metric))
+}
+
+func (s *StatsDSink) RecordRequest(tags Tags) {
+	s.send(fmt.Sprintf("erpc.request:1|c|#project:%s,chain_id:%d,method:%s", tags.ProjectId, tags.ChainId, tags.Method))
+}
+
+func (s *StatsDSink) RecordUpstreamCall(tags Tags, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	s.send(fmt.Sprintf("erpc.upstream_call:1|c|#project:%s,upstream:%s,chain_id:%d,method:%s,outcome:%s",
+		tags.ProjectId, tags.UpstreamId, tags.ChainId, tags.Method, outcome))
+	s.send(fmt.Sprintf("erpc.upstream_call.duration:%d|ms|#project:%s,upstream:%s,chain_id:%d,method:%s",
+		duration.Milliseconds(), tags.ProjectId, tags.UpstreamId, tags.ChainId, tags.Method))
+}
+
+func (s *StatsDSink) RecordCacheHit(tags Tags) {
+	s.send(fmt.Sprintf("erpc.cache_hit:1|c|#project:%s,method:%s", tags.ProjectId, tags.Method))
+}
+
+func (s *StatsDSink) Flush() error { return nil }
+func (s *StatsDSink) Close() error { return s.conn.Close() }
@@ -0,0 +1,172 @@
+// Package docker discovers upstreams from local Docker container labels,
+// so an operator can add/remove an EVM node container and have erpc pick
+// it up without editing YAML.
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/flair-sdk/erpc/config"
+	"github.com/rs/zerolog"
+)
+
+// Discoverer talks to the Docker Engine API over a Unix socket to
+// enumerate containers and watch their lifecycle events.
+type Discoverer struct {
+	cfg    *config.DockerDiscoveryConfig
+	client *http.Client
+	logger zerolog.Logger
+}
+
+func NewDiscoverer(cfg *config.DockerDiscoveryConfig, logger zerolog.Logger) *Discoverer {
+	socket := cfg.Socket
+	if socket == "" {
+		socket = "/var/run/docker.sock"
+	}
+
+	return &Discoverer{
+		cfg: cfg,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+			Timeout: 5 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+type containerSummary struct {
+	Id     string            `json:"Id"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// Discover enumerates currently running containers and returns the
+// upstreams derived from their labelPrefix+"upstream.*" labels. It
+// degrades gracefully (empty slice, nil error) when the Docker socket is
+// unreachable, since discovery is always additive on top of statically
+// configured upstreams, never a hard dependency for erpc to start.
+func (d *Discoverer) Discover(ctx context.Context) ([]*config.UpstreamConfig, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker/containers/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("docker discovery: socket unavailable, skipping")
+		return nil, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("docker discovery: unexpected status %d from Docker API", resp.StatusCode)
+	}
+
+	var containers []containerSummary
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, fmt.Errorf("docker discovery: failed to decode containers: %w", err)
+	}
+
+	upstreams := make([]*config.UpstreamConfig, 0, len(containers))
+	for _, c := range containers {
+		if uc := d.upstreamFromLabels(c.Labels); uc != nil {
+			upstreams = append(upstreams, uc)
+		}
+	}
+
+	return upstreams, nil
+}
+
+func (d *Discoverer) upstreamFromLabels(labels map[string]string) *config.UpstreamConfig {
+	prefix := d.cfg.LabelPrefix
+	if prefix == "" {
+		prefix = "erpc."
+	}
+
+	id := labels[prefix+"upstream.id"]
+	endpoint := labels[prefix+"upstream.endpoint"]
+	if id == "" || endpoint == "" {
+		return nil
+	}
+
+	uc := &config.UpstreamConfig{
+		Id:       id,
+		Endpoint: endpoint,
+		Group:    labels[prefix+"upstream.group"],
+		Labels:   labels,
+	}
+
+	if raw := labels[prefix+"upstream.evmChainId"]; raw != "" {
+		if chainId, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			uc.Metadata = &config.MetadataConfig{EvmChainId: chainId}
+		}
+	}
+
+	return uc
+}
+
+// Watch streams Docker's /events endpoint and calls onChange with a
+// freshly discovered upstream list every time a container starts,
+// stops, or dies. Callers (see Init's reload machinery) are expected to
+// rebuild/swap their upstream registry from the result the same way a
+// SIGHUP config reload does. Watch retries the event stream on any
+// error until ctx is canceled.
+func (d *Discoverer) Watch(ctx context.Context, onChange func([]*config.UpstreamConfig)) {
+	for ctx.Err() == nil {
+		if err := d.watchOnce(ctx, onChange); err != nil && ctx.Err() == nil {
+			d.logger.Warn().Err(err).Msg("docker discovery: event stream interrupted, retrying")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (d *Discoverer) watchOnce(ctx context.Context, onChange func([]*config.UpstreamConfig)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, `http://docker/events?filters={"type":["container"]}`, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var evt struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			continue
+		}
+		if evt.Status != "start" && evt.Status != "die" && evt.Status != "stop" {
+			continue
+		}
+
+		upstreams, err := d.Discover(ctx)
+		if err != nil {
+			d.logger.Warn().Err(err).Msg("docker discovery: failed to re-enumerate containers after event")
+			continue
+		}
+		onChange(upstreams)
+	}
+
+	return scanner.Err()
+}
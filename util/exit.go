@@ -0,0 +1,15 @@
+package util
+
+import "os"
+
+// Exit codes returned by the erpc process on startup failures. Kept as
+// distinct constants (rather than a generic non-zero code) so operators
+// and tests can tell config problems apart from runtime/listener problems.
+const (
+	ExitCodeERPCStartFailed  = 1
+	ExitCodeHttpServerFailed = 2
+)
+
+// OsExit is a var (instead of a direct os.Exit call) so tests can stub it
+// out and assert on the exit code without killing the test binary.
+var OsExit = os.Exit